@@ -0,0 +1,272 @@
+// Command ttt-server exposes the game and engine packages to external
+// front-ends over two protocols: a JSON/HTTP API for web clients, and a
+// line-based stdin/stdout protocol (in the spirit of GTP/UCI) for GUIs
+// and bots that drive the engine as a subprocess. Both protocols share
+// the same match.Registry, so a game started over the text protocol can
+// be inspected over HTTP via /state/{id}. The text protocol itself only
+// acts on the game its own newgame started.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/stefanwuthrich/tic-tac-toe/game"
+	"github.com/stefanwuthrich/tic-tac-toe/match"
+)
+
+// defaultEngine, defaultSize, and defaultWinLength seed /new requests
+// and "newgame" commands that don't specify their own.
+const (
+	defaultEngine    = "solver"
+	defaultSize      = 3
+	defaultWinLength = 3
+)
+
+func main() {
+	addr := flag.String("http", ":8080", "address to serve the JSON/HTTP API on; empty disables it")
+	flag.Parse()
+
+	registry := match.NewRegistry()
+
+	if *addr == "" {
+		runTextProtocol(registry, os.Stdin, os.Stdout)
+		return
+	}
+
+	// Run the text protocol alongside the HTTP API rather than the other
+	// way around: stdin commonly closes immediately in HTTP-only use
+	// (e.g. launched by a supervisor with no interactive input), and that
+	// must not bring the HTTP server down with it.
+	go runTextProtocol(registry, os.Stdin, os.Stdout)
+
+	fmt.Fprintf(os.Stderr, "JSON/HTTP API listening on %s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, newHTTPHandler(registry)))
+}
+
+// --- JSON/HTTP API ---
+
+// newMatchRequest is the optional body of POST /new; any zero fields
+// fall back to the package defaults.
+type newMatchRequest struct {
+	Engine string `json:"engine"`
+	Size   int    `json:"size"`
+	Win    int    `json:"win"`
+}
+
+// moveRequest is the body of POST /move.
+type moveRequest struct {
+	GameID string `json:"game_id"`
+	Cell   string `json:"cell"`
+	Player string `json:"player"`
+}
+
+func newHTTPHandler(registry *match.Registry) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		handleNew(w, r, registry)
+	})
+	mux.HandleFunc("/move", func(w http.ResponseWriter, r *http.Request) {
+		handleMove(w, r, registry)
+	})
+	mux.HandleFunc("/state/", func(w http.ResponseWriter, r *http.Request) {
+		handleState(w, r, registry)
+	})
+	return mux
+}
+
+func handleNew(w http.ResponseWriter, r *http.Request, registry *match.Registry) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := newMatchRequest{Engine: defaultEngine, Size: defaultSize, Win: defaultWinLength}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	if req.Engine == "" {
+		req.Engine = defaultEngine
+	}
+	if req.Size == 0 {
+		req.Size = defaultSize
+	}
+	if req.Win == 0 {
+		req.Win = defaultWinLength
+	}
+
+	m, err := registry.Create(req.Engine, req.Size, req.Win)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, m.State())
+}
+
+func handleMove(w http.ResponseWriter, r *http.Request, registry *match.Registry) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req moveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Player == "" {
+		req.Player = game.PlayerX
+	}
+
+	m, ok := registry.Get(req.GameID)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no such game %q", req.GameID), http.StatusNotFound)
+		return
+	}
+
+	row, col, err := game.ParseMove(req.Cell, m.Size())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := m.Play(req.Player, row, col); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, m.State())
+}
+
+func handleState(w http.ResponseWriter, r *http.Request, registry *match.Registry) {
+	id := strings.TrimPrefix(r.URL.Path, "/state/")
+	m, ok := registry.Get(id)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no such game %q", id), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, m.State())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// --- Text protocol ---
+
+// runTextProtocol reads newline-terminated commands from in and writes
+// responses to out until it reads "quit" or in is exhausted. It keeps
+// a single "current" match, in the style of a GTP engine: newgame
+// starts it, and play/genmove/showboard act on it.
+//
+// Commands:
+//
+//	newgame [engine] [size] [win]   start a new game, defaults as in /new
+//	play <X|O> <cell>               play <cell> (e.g. "B2") for <X|O>
+//	genmove <X|O>                   have the engine move for <X|O>, reporting the cell
+//	showboard                       print the board
+//	quit                            end the session
+//
+// Each response ends with one line: "= <result>" on success, "? <error>"
+// on failure. showboard first prints the board itself, over several
+// lines, to standard output.
+func runTextProtocol(registry *match.Registry, in *os.File, out *os.File) {
+	scanner := bufio.NewScanner(in)
+	var current *match.Match
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		cmd, args := fields[0], fields[1:]
+
+		switch cmd {
+		case "quit":
+			fmt.Fprintln(out, "= bye")
+			return
+
+		case "newgame":
+			engineKind, size, win := defaultEngine, defaultSize, defaultWinLength
+			if len(args) > 0 {
+				engineKind = args[0]
+			}
+			if len(args) > 1 {
+				v, err := strconv.Atoi(args[1])
+				if err != nil {
+					fmt.Fprintf(out, "? invalid size %q: %v\n", args[1], err)
+					continue
+				}
+				size = v
+			}
+			if len(args) > 2 {
+				v, err := strconv.Atoi(args[2])
+				if err != nil {
+					fmt.Fprintf(out, "? invalid win %q: %v\n", args[2], err)
+					continue
+				}
+				win = v
+			}
+			m, err := registry.Create(engineKind, size, win)
+			if err != nil {
+				fmt.Fprintf(out, "? %v\n", err)
+				continue
+			}
+			current = m
+			fmt.Fprintf(out, "= %s\n", m.ID)
+
+		case "play":
+			if current == nil {
+				fmt.Fprintln(out, "? no game in progress; run newgame first")
+				continue
+			}
+			if len(args) != 2 {
+				fmt.Fprintln(out, "? usage: play <X|O> <cell>")
+				continue
+			}
+			row, col, err := game.ParseMove(args[1], current.Size())
+			if err != nil {
+				fmt.Fprintf(out, "? %v\n", err)
+				continue
+			}
+			if err := current.Play(args[0], row, col); err != nil {
+				fmt.Fprintf(out, "? %v\n", err)
+				continue
+			}
+			fmt.Fprintln(out, "= ok")
+
+		case "genmove":
+			if current == nil {
+				fmt.Fprintln(out, "? no game in progress; run newgame first")
+				continue
+			}
+			if len(args) != 1 {
+				fmt.Fprintln(out, "? usage: genmove <X|O>")
+				continue
+			}
+			mv, err := current.GenMove(args[0])
+			if err != nil {
+				fmt.Fprintf(out, "? %v\n", err)
+				continue
+			}
+			fmt.Fprintf(out, "= %s\n", match.FormatCell(mv.Row, mv.Col))
+
+		case "showboard":
+			if current == nil {
+				fmt.Fprintln(out, "? no game in progress; run newgame first")
+				continue
+			}
+			current.Print()
+			fmt.Fprintln(out, "= ok")
+
+		default:
+			fmt.Fprintf(out, "? unknown command %q\n", cmd)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package difficulty exposes human-facing strength tiers for the
+// computer player, from a uniformly random Easy move up to Perfect,
+// exhaustive minimax search that never loses.
+package difficulty
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/stefanwuthrich/tic-tac-toe/game"
+	"github.com/stefanwuthrich/tic-tac-toe/minimax"
+)
+
+// Level names a difficulty tier.
+type Level string
+
+// The supported difficulty tiers, in increasing strength.
+const (
+	Easy    Level = "easy"
+	Medium  Level = "medium"
+	Hard    Level = "hard"
+	Perfect Level = "perfect"
+)
+
+// hardDepth and hardTimeBudget keep Hard searching only a few plies
+// deep, so it plays strong moves but, unlike Perfect, can still be
+// beaten.
+const (
+	hardDepth      = 3
+	hardTimeBudget = 200 * time.Millisecond
+)
+
+// New builds the game.Engine for level, sized for an N×N board.
+func New(level Level, size int) (game.Engine, error) {
+	switch level {
+	case Easy:
+		return NewEasy(), nil
+	case Medium:
+		return NewMedium(), nil
+	case Hard:
+		return NewHard(size), nil
+	case Perfect:
+		return NewPerfect(size), nil
+	default:
+		return nil, fmt.Errorf("unknown difficulty %q (want easy, medium, hard, or perfect)", level)
+	}
+}
+
+// easyEngine picks a uniformly random legal move.
+type easyEngine struct {
+	rng *rand.Rand
+}
+
+// NewEasy returns an Easy-difficulty engine.
+func NewEasy() game.Engine {
+	return &easyEngine{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// BestMove returns a uniformly random legal move.
+func (e *easyEngine) BestMove(b game.Board, player string) (int, int) {
+	moves := b.AvailableMoves()
+	if len(moves) == 0 {
+		return -1, -1
+	}
+	mv := moves[e.rng.Intn(len(moves))]
+	return mv.Row, mv.Col
+}
+
+// mediumEngine looks one ply ahead: it takes an immediate win if one is
+// available, else blocks the opponent's immediate win, else falls back
+// to a center/corner/edge positional preference.
+type mediumEngine struct {
+	rng *rand.Rand
+}
+
+// NewMedium returns a Medium-difficulty engine.
+func NewMedium() game.Engine {
+	return &mediumEngine{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// BestMove implements the win/block/positional heuristic described on
+// mediumEngine.
+func (m *mediumEngine) BestMove(b game.Board, player string) (int, int) {
+	moves := b.AvailableMoves()
+	if len(moves) == 0 {
+		return -1, -1
+	}
+
+	opponent := game.PlayerO
+	if player == game.PlayerO {
+		opponent = game.PlayerX
+	}
+
+	if mv, ok := winningMove(b, moves, player); ok {
+		return mv.Row, mv.Col
+	}
+	if mv, ok := winningMove(b, moves, opponent); ok {
+		return mv.Row, mv.Col
+	}
+
+	best := []game.Move{moves[0]}
+	bestRank := positionRank(b, moves[0])
+	for _, mv := range moves[1:] {
+		switch rank := positionRank(b, mv); {
+		case rank > bestRank:
+			best, bestRank = []game.Move{mv}, rank
+		case rank == bestRank:
+			best = append(best, mv)
+		}
+	}
+	mv := best[m.rng.Intn(len(best))]
+	return mv.Row, mv.Col
+}
+
+// winningMove reports a move in moves that immediately wins for mark,
+// if any.
+func winningMove(b game.Board, moves []game.Move, mark string) (game.Move, bool) {
+	for _, mv := range moves {
+		trial := b.Clone()
+		trial.Set(mv.Row, mv.Col, mark)
+		if trial.CheckWin(mark) {
+			return mv, true
+		}
+	}
+	return game.Move{}, false
+}
+
+// positionRank scores mv by the classic tic-tac-toe opening preference:
+// center highest, then corners, then edges.
+func positionRank(b game.Board, mv game.Move) int {
+	size := b.Size
+	if size%2 == 1 && mv.Row == size/2 && mv.Col == size/2 {
+		return 2
+	}
+	if (mv.Row == 0 || mv.Row == size-1) && (mv.Col == 0 || mv.Col == size-1) {
+		return 1
+	}
+	return 0
+}
+
+// hardEngine wraps minimax.Engine with a shallow search depth, trading
+// perfect play for speed and a human-beatable opponent.
+type hardEngine struct {
+	engine *minimax.Engine
+}
+
+// NewHard returns a Hard-difficulty engine sized for an N×N board.
+func NewHard(size int) game.Engine {
+	return &hardEngine{engine: minimax.NewEngine(size, hardDepth, hardTimeBudget)}
+}
+
+// BestMove delegates to the wrapped minimax.Engine.
+func (h *hardEngine) BestMove(b game.Board, player string) (int, int) {
+	return h.engine.BestMove(b, player)
+}
+
+// NodesSearched returns the wrapped minimax.Engine's node count for its
+// most recent move.
+func (h *hardEngine) NodesSearched() int {
+	return h.engine.NodesSearched()
+}
+
+// perfectEngine wraps minimax.Engine configured to search exhaustively,
+// so it never loses.
+type perfectEngine struct {
+	engine *minimax.Engine
+}
+
+// NewPerfect returns a Perfect-difficulty engine sized for an N×N board.
+func NewPerfect(size int) game.Engine {
+	return &perfectEngine{engine: minimax.NewExhaustiveEngine(size)}
+}
+
+// BestMove delegates to the wrapped minimax.Engine.
+func (p *perfectEngine) BestMove(b game.Board, player string) (int, int) {
+	return p.engine.BestMove(b, player)
+}
+
+// NodesSearched returns the wrapped minimax.Engine's node count for its
+// most recent move.
+func (p *perfectEngine) NodesSearched() int {
+	return p.engine.NodesSearched()
+}
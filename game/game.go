@@ -0,0 +1,202 @@
+// Package game implements a generalized Connect-N style board: an N×N
+// grid on which a player wins by placing K marks in a row, in any row,
+// column, or diagonal. Standard tic-tac-toe is the N=3, K=3 case; larger
+// N and K give 4x4x4, 5-in-a-row, Gomoku, and so on.
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constants for players and empty cell.
+const (
+	PlayerX   = "X"
+	PlayerO   = "O" // Computer will be 'O'
+	EmptyCell = " "
+)
+
+// Move is a zero-indexed board coordinate.
+type Move struct {
+	Row, Col int
+}
+
+// Engine selects a move for player to move on board b.
+type Engine interface {
+	BestMove(b Board, player string) (int, int)
+}
+
+// Board is an N×N grid of marks, won by placing WinLength marks in a
+// row, column, or diagonal.
+type Board struct {
+	Size      int
+	WinLength int
+	cells     [][]string
+}
+
+// NewBoard creates an empty size×size board on which winLength marks in
+// a row, column, or diagonal wins the game.
+func NewBoard(size, winLength int) Board {
+	cells := make([][]string, size)
+	for i := range cells {
+		cells[i] = make([]string, size)
+		for j := range cells[i] {
+			cells[i][j] = EmptyCell
+		}
+	}
+	return Board{Size: size, WinLength: winLength, cells: cells}
+}
+
+// Get returns the mark at (row, col).
+func (b Board) Get(row, col int) string {
+	return b.cells[row][col]
+}
+
+// Set places a mark at (row, col).
+func (b Board) Set(row, col int, mark string) {
+	b.cells[row][col] = mark
+}
+
+// Clone returns a deep copy of the board, so the clone's cells can be
+// mutated independently of b's.
+func (b Board) Clone() Board {
+	clone := NewBoard(b.Size, b.WinLength)
+	for i := range b.cells {
+		copy(clone.cells[i], b.cells[i])
+	}
+	return clone
+}
+
+// Print displays the current state of the board. Rows are labelled A,
+// B, C… and columns 1, 2, 3… so a move can be entered as e.g. "B3".
+func (b Board) Print() {
+	fmt.Println()
+	sep := "  " + strings.Repeat("----", b.Size) + "-"
+	fmt.Println(sep)
+	for i := 0; i < b.Size; i++ {
+		fmt.Printf("%c ", 'A'+i)
+		for j := 0; j < b.Size; j++ {
+			fmt.Printf("| %s ", b.cells[i][j])
+		}
+		fmt.Println("|")
+		fmt.Println(sep)
+	}
+	fmt.Print("  ")
+	for j := 0; j < b.Size; j++ {
+		fmt.Printf("  %d ", j+1)
+	}
+	fmt.Println()
+}
+
+// AvailableMoves returns the coordinates of every empty cell.
+func (b Board) AvailableMoves() []Move {
+	var moves []Move
+	for i := 0; i < b.Size; i++ {
+		for j := 0; j < b.Size; j++ {
+			if b.cells[i][j] == EmptyCell {
+				moves = append(moves, Move{i, j})
+			}
+		}
+	}
+	return moves
+}
+
+// IsFull reports whether there are no empty cells left.
+func (b Board) IsFull() bool {
+	return len(b.AvailableMoves()) == 0
+}
+
+// lineDirections are the four directions CheckWin scans from each mark:
+// horizontal, vertical, and both diagonals.
+var lineDirections = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// CheckWin reports whether player has WinLength marks in a row along
+// any row, column, or diagonal. Each mark is scanned once per
+// direction, incrementally extending a run rather than re-testing whole
+// lines, so the check stays cheap as the board grows.
+func (b Board) CheckWin(player string) bool {
+	for i := 0; i < b.Size; i++ {
+		for j := 0; j < b.Size; j++ {
+			if b.cells[i][j] != player {
+				continue
+			}
+			if b.WinLength <= 1 {
+				return true
+			}
+			for _, d := range lineDirections {
+				run := 1
+				r, c := i+d[0], j+d[1]
+				for r >= 0 && r < b.Size && c >= 0 && c < b.Size && b.cells[r][c] == player {
+					run++
+					if run >= b.WinLength {
+						return true
+					}
+					r += d[0]
+					c += d[1]
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Status describes the outcome of a board, or StatusPlaying if the
+// game has not yet ended.
+type Status string
+
+// The possible values of Status.
+const (
+	StatusPlaying Status = "playing"
+	StatusWinX    Status = "x_win"
+	StatusWinO    Status = "o_win"
+	StatusDraw    Status = "draw"
+)
+
+// Status reports b's current outcome.
+func (b Board) Status() Status {
+	switch {
+	case b.CheckWin(PlayerX):
+		return StatusWinX
+	case b.CheckWin(PlayerO):
+		return StatusWinO
+	case b.IsFull():
+		return StatusDraw
+	default:
+		return StatusPlaying
+	}
+}
+
+// EvaluateBoard assigns a score for the Minimax algorithm: +10 for a
+// PlayerO win, -10 for a PlayerX win, 0 for a draw or ongoing game.
+func (b Board) EvaluateBoard() int {
+	if b.CheckWin(PlayerO) {
+		return 10
+	}
+	if b.CheckWin(PlayerX) {
+		return -10
+	}
+	return 0
+}
+
+// ParseMove parses a coordinate like "B3" (a row letter followed by a
+// 1-indexed column number) into zero-indexed (row, col), validating it
+// against the board size.
+func ParseMove(input string, size int) (row, col int, err error) {
+	input = strings.ToUpper(strings.TrimSpace(input))
+	if len(input) < 2 {
+		return 0, 0, fmt.Errorf("move must be a row letter followed by a column number, e.g. %q", "B3")
+	}
+
+	row = int(input[0] - 'A')
+	col, err = strconv.Atoi(input[1:])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid column in %q: %w", input, err)
+	}
+	col--
+
+	if row < 0 || row >= size || col < 0 || col >= size {
+		return 0, 0, fmt.Errorf("move %q is outside the %dx%d board", input, size, size)
+	}
+	return row, col, nil
+}
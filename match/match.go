@@ -0,0 +1,221 @@
+// Package match manages live game sessions on top of the game and
+// engine packages, so a front-end only needs to hold a Match ID and
+// call Play/GenMove/State instead of wiring a Board and Engine itself.
+// It backs both the JSON/HTTP API and the text protocol served by
+// cmd/ttt-server.
+package match
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/stefanwuthrich/tic-tac-toe/difficulty"
+	"github.com/stefanwuthrich/tic-tac-toe/game"
+	"github.com/stefanwuthrich/tic-tac-toe/mcts"
+	"github.com/stefanwuthrich/tic-tac-toe/minimax"
+	"github.com/stefanwuthrich/tic-tac-toe/solver"
+)
+
+// NewEngine builds the game.Engine named by kind: "solver" (precomputed
+// 3x3 game tree), "minimax" (alpha-beta negamax), "mcts" (Monte Carlo
+// Tree Search), or one of the difficulty.Level tiers ("easy", "medium",
+// "hard", "perfect").
+func NewEngine(kind string, size int) (game.Engine, error) {
+	switch kind {
+	case "solver":
+		return solver.NewSolver(), nil
+	case "minimax":
+		return minimax.NewExhaustiveEngine(size), nil
+	case "mcts":
+		return mcts.NewEngine(2000), nil
+	case string(difficulty.Easy), string(difficulty.Medium), string(difficulty.Hard), string(difficulty.Perfect):
+		return difficulty.New(difficulty.Level(kind), size)
+	default:
+		return nil, fmt.Errorf("unknown engine %q (want solver, minimax, mcts, easy, medium, hard, or perfect)", kind)
+	}
+}
+
+// Match is one in-progress (or finished) game, paired with the engine
+// that plays moves for the computer side.
+type Match struct {
+	ID         string
+	EngineKind string
+
+	mu     sync.Mutex
+	board  game.Board
+	engine game.Engine
+}
+
+// State is a snapshot of a Match suitable for returning to a caller: the
+// board's marks, its status, the cells still open, and, while the game
+// is still playing, the move the engine suggests next.
+type State struct {
+	ID             string     `json:"id"`
+	Size           int        `json:"size"`
+	Board          [][]string `json:"board"`
+	Status         string     `json:"status"`
+	AvailableMoves []string   `json:"available_moves"`
+	SuggestedMove  string     `json:"suggested_move,omitempty"`
+}
+
+// New creates a Match with id on a fresh size×size, winLength-to-win
+// board, using a newly built engine of kind.
+func New(id, engineKind string, size, winLength int) (*Match, error) {
+	engine, err := NewEngine(engineKind, size)
+	if err != nil {
+		return nil, err
+	}
+	return &Match{
+		ID:         id,
+		EngineKind: engineKind,
+		board:      game.NewBoard(size, winLength),
+		engine:     engine,
+	}, nil
+}
+
+// Play places player's mark at (row, col), rejecting the move if the
+// game has already ended, the cell is occupied, or the coordinates are
+// out of range.
+func (m *Match) Play(player string, row, col int) error {
+	if player != game.PlayerX && player != game.PlayerO {
+		return fmt.Errorf("invalid player %q (want %q or %q)", player, game.PlayerX, game.PlayerO)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.board.Status() != game.StatusPlaying {
+		return fmt.Errorf("game %s has already ended", m.ID)
+	}
+	if row < 0 || row >= m.board.Size || col < 0 || col >= m.board.Size {
+		return fmt.Errorf("move (%d,%d) is outside the %dx%d board", row, col, m.board.Size, m.board.Size)
+	}
+	if m.board.Get(row, col) != game.EmptyCell {
+		return fmt.Errorf("cell (%d,%d) is already taken", row, col)
+	}
+
+	m.board.Set(row, col, player)
+	return nil
+}
+
+// GenMove asks the engine for player's best move, plays it, and returns
+// the coordinates chosen.
+func (m *Match) GenMove(player string) (game.Move, error) {
+	if player != game.PlayerX && player != game.PlayerO {
+		return game.Move{}, fmt.Errorf("invalid player %q (want %q or %q)", player, game.PlayerX, game.PlayerO)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.board.Status() != game.StatusPlaying {
+		return game.Move{}, fmt.Errorf("game %s has already ended", m.ID)
+	}
+
+	row, col := m.engine.BestMove(m.board, player)
+	if row == -1 {
+		return game.Move{}, fmt.Errorf("engine could not find a move for %s", player)
+	}
+	m.board.Set(row, col, player)
+	return game.Move{Row: row, Col: col}, nil
+}
+
+// Size returns the board's side length.
+func (m *Match) Size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.board.Size
+}
+
+// State returns a snapshot of m's current board, status, and suggested
+// next move.
+func (m *Match) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	size := m.board.Size
+	rows := make([][]string, size)
+	for i := 0; i < size; i++ {
+		row := make([]string, size)
+		for j := 0; j < size; j++ {
+			row[j] = m.board.Get(i, j)
+		}
+		rows[i] = row
+	}
+
+	status := m.board.Status()
+	var available []string
+	for _, mv := range m.board.AvailableMoves() {
+		available = append(available, FormatCell(mv.Row, mv.Col))
+	}
+
+	var suggested string
+	if status == game.StatusPlaying {
+		row, col := m.engine.BestMove(m.board, game.PlayerO)
+		if row != -1 {
+			suggested = FormatCell(row, col)
+		}
+	}
+
+	return State{
+		ID:             m.ID,
+		Size:           size,
+		Board:          rows,
+		Status:         string(status),
+		AvailableMoves: available,
+		SuggestedMove:  suggested,
+	}
+}
+
+// Print writes m's board to stdout via game.Board.Print.
+func (m *Match) Print() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.board.Print()
+}
+
+// FormatCell renders a zero-indexed (row, col) as a coordinate like
+// "B3", matching the format game.ParseMove accepts.
+func FormatCell(row, col int) string {
+	return fmt.Sprintf("%c%d", 'A'+row, col+1)
+}
+
+// Registry hands out sequentially-numbered Match IDs and keeps the
+// resulting Matches available for lookup, guarded by a mutex so
+// concurrent HTTP requests can share it safely.
+type Registry struct {
+	mu     sync.Mutex
+	nextID int
+	games  map[string]*Match
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{games: make(map[string]*Match)}
+}
+
+// Create builds a new Match, assigns it the next sequential ID, and
+// stores it for later lookup via Get.
+func (r *Registry) Create(engineKind string, size, winLength int) (*Match, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	id := strconv.Itoa(r.nextID)
+	m, err := New(id, engineKind, size, winLength)
+	if err != nil {
+		r.nextID--
+		return nil, err
+	}
+	r.games[id] = m
+	return m, nil
+}
+
+// Get returns the Match with the given id, if any.
+func (r *Registry) Get(id string) (*Match, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.games[id]
+	return m, ok
+}
@@ -0,0 +1,189 @@
+// Package mcts implements Monte Carlo Tree Search (UCT) as an
+// alternative to exhaustive minimax search, scaling to boards where
+// full search is infeasible.
+package mcts
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/stefanwuthrich/tic-tac-toe/game"
+)
+
+// explorationConstant is the classic UCT exploration weight, c = sqrt(2)
+// rounded, trading off exploitation of known-good moves against
+// exploring untried ones.
+const explorationConstant = 1.41
+
+// node is one position in the UCT search tree.
+type node struct {
+	state           game.Board
+	parent          *node
+	children        []*node
+	untriedMoves    []game.Move
+	visits          int
+	wins            float64
+	playerJustMoved string
+	move            game.Move
+}
+
+func newNode(state game.Board, parent *node, playerJustMoved string, move game.Move) *node {
+	n := &node{
+		state:           state,
+		parent:          parent,
+		playerJustMoved: playerJustMoved,
+		move:            move,
+	}
+	// A terminal position (someone already won, or the board is full) has
+	// no moves left to try, so select/expand stop descending past it.
+	if !state.CheckWin(game.PlayerX) && !state.CheckWin(game.PlayerO) && !state.IsFull() {
+		n.untriedMoves = state.AvailableMoves()
+	}
+	return n
+}
+
+// uctSelectChild picks the child maximizing the UCB1 score.
+func (n *node) uctSelectChild() *node {
+	var best *node
+	bestScore := math.Inf(-1)
+	for _, c := range n.children {
+		score := c.wins/float64(c.visits) + explorationConstant*math.Sqrt(math.Log(float64(n.visits))/float64(c.visits))
+		if score > bestScore {
+			bestScore = score
+			best = c
+		}
+	}
+	return best
+}
+
+// expand pops one untried move, adds the resulting position as a child,
+// and returns it.
+func (n *node) expand(rng *rand.Rand) *node {
+	i := rng.Intn(len(n.untriedMoves))
+	mv := n.untriedMoves[i]
+	n.untriedMoves = append(n.untriedMoves[:i], n.untriedMoves[i+1:]...)
+
+	mover := other(n.playerJustMoved)
+	child := n.state.Clone()
+	child.Set(mv.Row, mv.Col, mover)
+
+	childNode := newNode(child, n, mover, mv)
+	n.children = append(n.children, childNode)
+	return childNode
+}
+
+// update records a playout result from this node's own perspective.
+func (n *node) update(result float64) {
+	n.visits++
+	n.wins += result
+}
+
+func other(player string) string {
+	if player == game.PlayerO {
+		return game.PlayerX
+	}
+	return game.PlayerO
+}
+
+// Engine selects moves via Upper Confidence bounds applied to Trees
+// (UCT): it runs a fixed number of playouts from the current position
+// and returns the root child visited most often.
+type Engine struct {
+	iterations int
+	rng        *rand.Rand
+}
+
+// NewEngine builds an Engine that runs the given number of MCTS
+// iterations before choosing each move.
+func NewEngine(iterations int) *Engine {
+	return &Engine{
+		iterations: iterations,
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// BestMove runs UCT from board b for the configured number of
+// iterations and returns the most-visited root child.
+func (e *Engine) BestMove(b game.Board, player string) (int, int) {
+	root := newNode(b.Clone(), nil, other(player), game.Move{Row: -1, Col: -1})
+
+	for i := 0; i < e.iterations; i++ {
+		n := root
+
+		// Select: descend the tree by UCB1 while every move has been tried.
+		for len(n.untriedMoves) == 0 && len(n.children) > 0 {
+			n = n.uctSelectChild()
+		}
+
+		// Expand: try one new move from this position, if any remain.
+		if len(n.untriedMoves) > 0 {
+			n = n.expand(e.rng)
+		}
+
+		// Simulate: play random moves to a terminal state.
+		winner := e.rollout(n)
+
+		// Backpropagate: update every node on the path from n to the root.
+		for ; n != nil; n = n.parent {
+			result := 0.5
+			if winner != "" {
+				if winner == n.playerJustMoved {
+					result = 1
+				} else {
+					result = 0
+				}
+			}
+			n.update(result)
+		}
+	}
+
+	if len(root.children) == 0 {
+		moves := b.AvailableMoves()
+		if len(moves) == 0 {
+			return -1, -1
+		}
+		mv := moves[e.rng.Intn(len(moves))]
+		return mv.Row, mv.Col
+	}
+
+	best := root.children[0]
+	for _, c := range root.children[1:] {
+		if c.visits > best.visits {
+			best = c
+		}
+	}
+	return best.move.Row, best.move.Col
+}
+
+// NodesSearched returns the number of playouts BestMove runs per move,
+// i.e. its configured iteration count.
+func (e *Engine) NodesSearched() int {
+	return e.iterations
+}
+
+// rollout plays random legal moves from n's state to a terminal
+// position and returns the winning player, or "" for a draw.
+func (e *Engine) rollout(n *node) string {
+	state := n.state.Clone()
+	mover := n.playerJustMoved
+
+	for {
+		if state.CheckWin(mover) || state.IsFull() {
+			break
+		}
+		mover = other(mover)
+		moves := state.AvailableMoves()
+		mv := moves[e.rng.Intn(len(moves))]
+		state.Set(mv.Row, mv.Col, mover)
+	}
+
+	switch {
+	case state.CheckWin(game.PlayerX):
+		return game.PlayerX
+	case state.CheckWin(game.PlayerO):
+		return game.PlayerO
+	default:
+		return ""
+	}
+}
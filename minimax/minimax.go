@@ -0,0 +1,267 @@
+// Package minimax implements an alpha-beta pruned negamax search with
+// iterative deepening and a Zobrist-hashed transposition table, used to
+// find the computer's move on a game.Board.
+package minimax
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/stefanwuthrich/tic-tac-toe/game"
+)
+
+// infinity bounds the search window. Using a value well short of
+// math.MaxInt32 keeps -beta/-alpha from overflowing when negated.
+const infinity = 1 << 30
+
+// ttFlag records whether a transposition-table entry holds an exact
+// value or a bound produced by alpha-beta pruning.
+type ttFlag int
+
+const (
+	exact ttFlag = iota
+	lower
+	upper
+)
+
+type ttEntry struct {
+	depth    int
+	value    int
+	flag     ttFlag
+	bestMove game.Move
+}
+
+// Engine is an alpha-beta negamax search with iterative deepening and a
+// transposition table keyed by an incremental Zobrist hash of the
+// board.
+type Engine struct {
+	maxDepth   int
+	timeBudget time.Duration
+	zobrist    [][][2]uint64
+	tt         map[uint64]ttEntry
+	timedOut   bool
+	nodes      int
+	mateValue  int
+}
+
+// NewEngine builds an Engine for a board of the given size, searching up
+// to maxDepth plies per move, bailing out of a search early once
+// timeBudget has elapsed.
+func NewEngine(size, maxDepth int, timeBudget time.Duration) *Engine {
+	r := rand.New(rand.NewSource(1))
+	zobrist := make([][][2]uint64, size)
+	for i := range zobrist {
+		zobrist[i] = make([][2]uint64, size)
+		for j := range zobrist[i] {
+			zobrist[i][j] = [2]uint64{r.Uint64(), r.Uint64()}
+		}
+	}
+	return &Engine{
+		maxDepth:   maxDepth,
+		timeBudget: timeBudget,
+		zobrist:    zobrist,
+		tt:         make(map[uint64]ttEntry),
+		// mateValue must exceed the deepest ply a single BestMove search
+		// can reach (bounded by maxDepth) so that every win outscores
+		// every draw (0) and every draw outscores every loss, however
+		// far into the game the mate is found. A fixed small constant
+		// like 10 only holds on boards where maxDepth < 10.
+		mateValue: maxDepth + 1,
+	}
+}
+
+// exhaustiveTimeBudget bounds NewExhaustiveEngine's search; 2s is ample
+// for a full-depth search of the small boards this engine targets.
+const exhaustiveTimeBudget = 2 * time.Second
+
+// NewExhaustiveEngine builds an Engine that searches every ply of a
+// size×size board, the configuration needed for optimal, unbeatable
+// play.
+func NewExhaustiveEngine(size int) *Engine {
+	return NewEngine(size, size*size, exhaustiveTimeBudget)
+}
+
+func pieceIndex(player string) int {
+	if player == game.PlayerO {
+		return 0
+	}
+	return 1
+}
+
+func other(player string) string {
+	if player == game.PlayerO {
+		return game.PlayerX
+	}
+	return game.PlayerO
+}
+
+func (e *Engine) hash(b game.Board) uint64 {
+	var h uint64
+	for i := 0; i < b.Size; i++ {
+		for j := 0; j < b.Size; j++ {
+			switch b.Get(i, j) {
+			case game.PlayerO:
+				h ^= e.zobrist[i][j][0]
+			case game.PlayerX:
+				h ^= e.zobrist[i][j][1]
+			}
+		}
+	}
+	return h
+}
+
+// BestMove runs iterative deepening from depth 1 up to maxDepth (or
+// until the time budget runs out) and returns the best move found for
+// player at the deepest completed iteration.
+func (e *Engine) BestMove(b game.Board, player string) (int, int) {
+	deadline := time.Now().Add(e.timeBudget)
+	hash := e.hash(b)
+	best := game.Move{Row: -1, Col: -1}
+	e.nodes = 0
+
+	// The transposition table's cached scores embed a ply count relative
+	// to this call's root (see negamax), so entries left over from an
+	// earlier BestMove call — whose root, and therefore whose ply-zero
+	// reference, was a different board — would be stale here even when
+	// their hash happens to match. Starting clean keeps cross-depth
+	// reuse within this call (iterative deepening) while discarding
+	// anything that doesn't apply.
+	e.tt = make(map[uint64]ttEntry)
+
+	for depth := 1; depth <= e.maxDepth; depth++ {
+		e.timedOut = false
+		_, move := e.negamax(b, hash, depth, 0, -infinity, infinity, player, deadline)
+		if e.timedOut {
+			break
+		}
+		if move.Row != -1 {
+			best = move
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+	}
+
+	if best.Row == -1 {
+		moves := b.AvailableMoves()
+		if len(moves) == 0 {
+			return -1, -1
+		}
+		best = moves[0]
+	}
+	return best.Row, best.Col
+}
+
+// NodesSearched returns the number of board positions negamax visited
+// while finding the most recent BestMove call's answer.
+func (e *Engine) NodesSearched() int {
+	return e.nodes
+}
+
+// negamax searches board b to the given depth from player's
+// perspective: the returned value is always "good for player". ply
+// counts moves made so far in this search so that the depth-adjusted
+// scoring below prefers faster wins and slower losses.
+func (e *Engine) negamax(b game.Board, hash uint64, depth, ply, alpha, beta int, player string, deadline time.Time) (int, game.Move) {
+	noMove := game.Move{Row: -1, Col: -1}
+	e.nodes++
+
+	if time.Now().After(deadline) {
+		e.timedOut = true
+		return 0, noMove
+	}
+
+	opponent := other(player)
+	if b.CheckWin(opponent) {
+		return ply - e.mateValue, noMove // opponent just moved and won: bad for player, worse the sooner it happened
+	}
+
+	moves := b.AvailableMoves()
+	if depth == 0 || len(moves) == 0 {
+		return 0, noMove
+	}
+
+	alphaOrig := alpha
+	var ttBest game.Move
+	ttBest.Row = -1
+	if entry, ok := e.tt[hash]; ok && entry.depth >= depth {
+		switch entry.flag {
+		case exact:
+			return entry.value, entry.bestMove
+		case lower:
+			if entry.value > alpha {
+				alpha = entry.value
+			}
+		case upper:
+			if entry.value < beta {
+				beta = entry.value
+			}
+		}
+		if alpha >= beta {
+			return entry.value, entry.bestMove
+		}
+		ttBest = entry.bestMove
+	}
+
+	orderMoves(moves, ttBest, b.Size)
+
+	best := -infinity
+	bestMove := moves[0]
+	for _, mv := range moves {
+		b.Set(mv.Row, mv.Col, player)
+		childHash := hash ^ e.zobrist[mv.Row][mv.Col][pieceIndex(player)]
+		score, _ := e.negamax(b, childHash, depth-1, ply+1, -beta, -alpha, opponent, deadline)
+		score = -score
+		b.Set(mv.Row, mv.Col, game.EmptyCell)
+
+		if e.timedOut {
+			return 0, noMove
+		}
+
+		if score > best {
+			best = score
+			bestMove = mv
+		}
+		if best > alpha {
+			alpha = best
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+
+	flag := exact
+	switch {
+	case best <= alphaOrig:
+		flag = upper
+	case best >= beta:
+		flag = lower
+	}
+	e.tt[hash] = ttEntry{depth: depth, value: best, flag: flag, bestMove: bestMove}
+
+	return best, bestMove
+}
+
+// orderMoves sorts moves so the transposition table's best move (if any)
+// is searched first, followed by moves closer to the board's center —
+// a cheap heuristic that tends to cut off alpha-beta search earlier.
+func orderMoves(moves []game.Move, ttBest game.Move, size int) {
+	center := float64(size-1) / 2
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i] == ttBest {
+			return true
+		}
+		if moves[j] == ttBest {
+			return false
+		}
+		return centerDistance(moves[i], center) < centerDistance(moves[j], center)
+	})
+}
+
+func centerDistance(mv game.Move, center float64) float64 {
+	dr := float64(mv.Row) - center
+	dc := float64(mv.Col) - center
+	return math.Hypot(dr, dc)
+}
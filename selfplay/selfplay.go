@@ -0,0 +1,107 @@
+// Package selfplay plays two engines against each other for a series
+// of games, alternating who opens, and reports aggregate win/loss/draw
+// rates alongside each side's average search cost. It is useful for
+// checking that a faster engine (MCTS, or the precomputed solver)
+// stays strategically equivalent to exhaustive minimax.
+package selfplay
+
+import (
+	"time"
+
+	"github.com/stefanwuthrich/tic-tac-toe/game"
+)
+
+// nodeCounter is implemented by engines that can report how much search
+// they did for their most recent move (minimax and mcts); engines
+// without it (solver, difficulty.Easy, difficulty.Medium) simply report
+// zero nodes.
+type nodeCounter interface {
+	NodesSearched() int
+}
+
+// Result is the aggregate outcome of a Run between engine A and engine
+// B.
+type Result struct {
+	Games        int
+	AWins, BWins int
+	Draws        int
+	AvgNodesA    float64
+	AvgNodesB    float64
+	AvgTimeA     time.Duration
+	AvgTimeB     time.Duration
+}
+
+// Run plays games games between engineA and engineB on a fresh
+// size×size, winLength-to-win board each time, alternating which
+// engine opens as PlayerX so neither side's first-move advantage skews
+// the aggregate rates, and returns the aggregate Result.
+func Run(engineA, engineB game.Engine, size, winLength, games int) Result {
+	res := Result{Games: games}
+
+	var nodesA, nodesB, movesA, movesB int
+	var timeA, timeB time.Duration
+
+	for i := 0; i < games; i++ {
+		aIsX := i%2 == 0
+		engines := map[string]game.Engine{game.PlayerX: engineB, game.PlayerO: engineA}
+		if aIsX {
+			engines = map[string]game.Engine{game.PlayerX: engineA, game.PlayerO: engineB}
+		}
+
+		b := game.NewBoard(size, winLength)
+		turn := game.PlayerX
+		for b.Status() == game.StatusPlaying {
+			engine := engines[turn]
+
+			start := time.Now()
+			row, col := engine.BestMove(b, turn)
+			elapsed := time.Since(start)
+			if row == -1 {
+				break
+			}
+			b.Set(row, col, turn)
+
+			nodes := 0
+			if nc, ok := engine.(nodeCounter); ok {
+				nodes = nc.NodesSearched()
+			}
+			if (turn == game.PlayerX) == aIsX {
+				nodesA += nodes
+				timeA += elapsed
+				movesA++
+			} else {
+				nodesB += nodes
+				timeB += elapsed
+				movesB++
+			}
+
+			turn = otherPlayer(turn)
+		}
+
+		switch status, xWasA := b.Status(), aIsX; {
+		case status == game.StatusWinX && xWasA, status == game.StatusWinO && !xWasA:
+			res.AWins++
+		case status == game.StatusWinX && !xWasA, status == game.StatusWinO && xWasA:
+			res.BWins++
+		case status == game.StatusDraw:
+			res.Draws++
+		}
+	}
+
+	if movesA > 0 {
+		res.AvgNodesA = float64(nodesA) / float64(movesA)
+		res.AvgTimeA = timeA / time.Duration(movesA)
+	}
+	if movesB > 0 {
+		res.AvgNodesB = float64(nodesB) / float64(movesB)
+		res.AvgTimeB = timeB / time.Duration(movesB)
+	}
+	return res
+}
+
+func otherPlayer(player string) string {
+	if player == game.PlayerX {
+		return game.PlayerO
+	}
+	return game.PlayerX
+}
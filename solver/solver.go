@@ -0,0 +1,303 @@
+// Package solver serves standard 3x3 tic-tac-toe moves from a complete
+// game tree, built once when a Solver is constructed. Every reachable
+// 3x3 position is canonicalized under the board's 8 D4 symmetries
+// (rotations and reflections), so equivalent positions share a single
+// precomputed entry and a move lookup is O(1) instead of a per-move
+// minimax search.
+package solver
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/stefanwuthrich/tic-tac-toe/game"
+)
+
+// GameStatus describes the terminal state of a 3x3 position, or
+// Playing if the game has not yet ended.
+type GameStatus int
+
+// The possible outcomes of a finished (or still ongoing) game.
+const (
+	Playing GameStatus = iota
+	WinX
+	WinO
+	Draw
+)
+
+// Cell values used by the internal [9]int board encoding.
+const (
+	empty = 0
+	markX = 1
+	markO = 2
+)
+
+const boardCells = 9
+
+// codeSpace is 3^9, the number of distinct base-3 codes a 3x3 board can
+// take before symmetry reduction.
+const codeSpace = 19683
+
+// node is the precomputed entry for one canonical position: its status,
+// and, while still playing, the canonical cell indices of every
+// optimally-valued move and that move's minimax weight.
+type node struct {
+	status GameStatus
+	moves  []int
+	weight int
+}
+
+// Solver answers BestMove queries for 3x3 tic-tac-toe via a lookup into
+// a precomputed table, with a random tiebreak among equally-good moves.
+type Solver struct {
+	nodes map[uint16]node
+	rng   *rand.Rand
+}
+
+// NewSolver builds the complete 3x3 game tree once and returns a Solver
+// ready to serve moves from it.
+func NewSolver() *Solver {
+	return &Solver{
+		nodes: build(),
+		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// BestMove looks up the canonical form of b and returns one of its
+// precomputed optimal moves, chosen at random among ties. b must be a
+// 3x3 board; boards of any other size have no entry and fall back to a
+// random legal move.
+func (s *Solver) BestMove(b game.Board, player string) (int, int) {
+	moves := b.AvailableMoves()
+	if len(moves) == 0 {
+		return -1, -1
+	}
+
+	if b.Size == 3 {
+		cells := boardToCells(b)
+		canon, symIdx := canonicalForm(cells)
+		if n, ok := s.nodes[canon]; ok && n.status == Playing && len(n.moves) > 0 {
+			_, perms := symmetries(cells)
+			canonMove := n.moves[s.rng.Intn(len(n.moves))]
+			origIdx := perms[symIdx][canonMove]
+			return origIdx / 3, origIdx % 3
+		}
+	}
+
+	mv := moves[s.rng.Intn(len(moves))]
+	return mv.Row, mv.Col
+}
+
+func boardToCells(b game.Board) [boardCells]int {
+	var cells [boardCells]int
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			switch b.Get(r, c) {
+			case game.PlayerX:
+				cells[r*3+c] = markX
+			case game.PlayerO:
+				cells[r*3+c] = markO
+			}
+		}
+	}
+	return cells
+}
+
+var winLines = [8][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, // rows
+	{0, 3, 6}, {1, 4, 7}, {2, 5, 8}, // columns
+	{0, 4, 8}, {2, 4, 6}, // diagonals
+}
+
+func status(cells [boardCells]int) GameStatus {
+	for _, line := range winLines {
+		a, b, c := cells[line[0]], cells[line[1]], cells[line[2]]
+		if a != empty && a == b && b == c {
+			if a == markX {
+				return WinX
+			}
+			return WinO
+		}
+	}
+	for _, v := range cells {
+		if v == empty {
+			return Playing
+		}
+	}
+	return Draw
+}
+
+func filledCount(cells [boardCells]int) int {
+	n := 0
+	for _, v := range cells {
+		if v != empty {
+			n++
+		}
+	}
+	return n
+}
+
+func otherMark(mark int) int {
+	if mark == markX {
+		return markO
+	}
+	return markX
+}
+
+func encode(cells [boardCells]int) uint16 {
+	var code uint16
+	pow := uint16(1)
+	for _, v := range cells {
+		code += uint16(v) * pow
+		pow *= 3
+	}
+	return code
+}
+
+func decode(code uint16) [boardCells]int {
+	var cells [boardCells]int
+	for i := 0; i < boardCells; i++ {
+		cells[i] = int(code % 3)
+		code /= 3
+	}
+	return cells
+}
+
+// rotate90 rotates a 3x3 array of cells (or, applied to the identity
+// permutation, a cell-index mapping) 90 degrees clockwise.
+func rotate90(cells [boardCells]int) [boardCells]int {
+	var out [boardCells]int
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			out[c*3+(2-r)] = cells[r*3+c]
+		}
+	}
+	return out
+}
+
+// reflect mirrors a 3x3 array of cells left-to-right.
+func reflect(cells [boardCells]int) [boardCells]int {
+	var out [boardCells]int
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			out[r*3+(2-c)] = cells[r*3+c]
+		}
+	}
+	return out
+}
+
+// symmetries returns all 8 D4 transforms of cells, paired with the cell
+// permutation each one applies (perms[i][newIndex] = originalIndex), so
+// a move found in a transformed board can be mapped back to cells'
+// original orientation.
+func symmetries(cells [boardCells]int) ([8][boardCells]int, [8][boardCells]int) {
+	identity := [boardCells]int{0, 1, 2, 3, 4, 5, 6, 7, 8}
+	var boards, perms [8][boardCells]int
+
+	b, p := cells, identity
+	for i := 0; i < 4; i++ {
+		boards[i], perms[i] = b, p
+		b, p = rotate90(b), rotate90(p)
+	}
+	b, p = reflect(cells), reflect(identity)
+	for i := 4; i < 8; i++ {
+		boards[i], perms[i] = b, p
+		b, p = rotate90(b), rotate90(p)
+	}
+	return boards, perms
+}
+
+// canonicalForm returns the lexicographically-smallest encoding among
+// cells' 8 symmetric variants, and the index of the symmetry that
+// produces it.
+func canonicalForm(cells [boardCells]int) (uint16, int) {
+	boards, _ := symmetries(cells)
+	bestCode, bestIdx := encode(boards[0]), 0
+	for i := 1; i < 8; i++ {
+		if c := encode(boards[i]); c < bestCode {
+			bestCode, bestIdx = c, i
+		}
+	}
+	return bestCode, bestIdx
+}
+
+// minimaxWeight computes the depth-adjusted minimax value of cells with
+// mark to move next: +/-10 for a win, adjusted by how many marks are on
+// the board so that faster wins score higher and slower losses score
+// less negatively, 0 for a draw.
+func minimaxWeight(cells [boardCells]int, mark int) int {
+	switch status(cells) {
+	case WinX:
+		return filledCount(cells) - 10
+	case WinO:
+		return 10 - filledCount(cells)
+	case Draw:
+		return 0
+	}
+
+	best := 0
+	first := true
+	for i, v := range cells {
+		if v != empty {
+			continue
+		}
+		next := cells
+		next[i] = mark
+		score := minimaxWeight(next, otherMark(mark))
+		switch {
+		case first:
+			best, first = score, false
+		case mark == markO && score > best, mark == markX && score < best:
+			best = score
+		}
+	}
+	return best
+}
+
+// build exhaustively enumerates every base-3 board code, keeping only
+// the canonical representative of each symmetry class, and records its
+// status and (while still playing) its optimal moves and their weight.
+func build() map[uint16]node {
+	nodes := make(map[uint16]node)
+
+	for code := 0; code < codeSpace; code++ {
+		cells := decode(uint16(code))
+		if canon, _ := canonicalForm(cells); canon != uint16(code) {
+			continue
+		}
+
+		st := status(cells)
+		if st != Playing {
+			nodes[uint16(code)] = node{status: st}
+			continue
+		}
+
+		turn := markX
+		if filledCount(cells)%2 == 1 {
+			turn = markO
+		}
+
+		var bestMoves []int
+		bestWeight := 0
+		first := true
+		for i, v := range cells {
+			if v != empty {
+				continue
+			}
+			next := cells
+			next[i] = turn
+			w := minimaxWeight(next, otherMark(turn))
+			switch {
+			case first:
+				bestWeight, bestMoves, first = w, []int{i}, false
+			case turn == markO && w > bestWeight, turn == markX && w < bestWeight:
+				bestWeight, bestMoves = w, []int{i}
+			case w == bestWeight:
+				bestMoves = append(bestMoves, i)
+			}
+		}
+		nodes[uint16(code)] = node{status: Playing, moves: bestMoves, weight: bestWeight}
+	}
+
+	return nodes
+}